@@ -0,0 +1,90 @@
+// Package cache provides a pluggable response cache used to shield the
+// TruckersMP upstream API from repeated requests for data that changes
+// infrequently.
+package cache
+
+import "time"
+
+// Entry is a single cached response, stored with enough metadata to
+// support conditional requests and stale-while-revalidate.
+type Entry struct {
+	Body       []byte
+	Header     map[string][]string
+	StatusCode int
+	ETag       string
+	StoredAt   time.Time
+	ExpiresAt  time.Time
+	// StaleUntil marks the end of the grace window during which a
+	// stale entry may still be served while a refresh happens in the
+	// background.
+	StaleUntil time.Time
+}
+
+// Fresh reports whether the entry has not yet passed its TTL.
+func (e Entry) Fresh(now time.Time) bool {
+	return now.Before(e.ExpiresAt)
+}
+
+// Stale reports whether the entry is expired but still within its
+// stale-while-revalidate grace window.
+func (e Entry) Stale(now time.Time) bool {
+	return !e.Fresh(now) && now.Before(e.StaleUntil)
+}
+
+// Cache is implemented by every cache backend (in-memory, Redis, ...).
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (Entry, bool)
+	Set(key string, entry Entry) error
+	Delete(key string) error
+	// Purge removes every entry whose key matches prefix, or every
+	// entry when prefix is empty. It returns the number removed.
+	Purge(prefix string) int
+}
+
+// RouteTTL is the default TTL applied to a route when the upstream
+// response carries no usable Cache-Control max-age directive.
+var RouteTTL = map[string]time.Duration{
+	"/servers":   30 * time.Second,
+	"/game_time": 5 * time.Second,
+	"/version":   time.Hour,
+	"/rules":     time.Hour,
+	"/player":    5 * time.Minute,
+}
+
+// staleGrace is how long past expiry a stale entry may still be served
+// while a background refresh is in flight.
+const staleGrace = 30 * time.Second
+
+// TTLFor returns the default TTL for endpoint, falling back to
+// defaultTTL when the route has no entry in RouteTTL. endpoint may
+// include a dynamic segment (e.g. "/player/123"); the longest matching
+// prefix in RouteTTL wins.
+func TTLFor(endpoint string, defaultTTL time.Duration) time.Duration {
+	best := defaultTTL
+	bestLen := -1
+	for prefix, ttl := range RouteTTL {
+		if len(prefix) > bestLen && hasPrefix(endpoint, prefix) {
+			best = ttl
+			bestLen = len(prefix)
+		}
+	}
+	return best
+}
+
+func hasPrefix(s, prefix string) bool {
+	return len(s) >= len(prefix) && s[:len(prefix)] == prefix
+}
+
+// NewEntry builds an Entry with ExpiresAt/StaleUntil derived from ttl.
+func NewEntry(body []byte, header map[string][]string, status int, etag string, ttl time.Duration, now time.Time) Entry {
+	return Entry{
+		Body:       body,
+		Header:     header,
+		StatusCode: status,
+		ETag:       etag,
+		StoredAt:   now,
+		ExpiresAt:  now.Add(ttl),
+		StaleUntil: now.Add(ttl + staleGrace),
+	}
+}
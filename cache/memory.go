@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// entrySize estimates the bytes an Entry occupies for the purposes of
+// the LRU's size cap. It doesn't need to be exact, just proportional.
+func entrySize(key string, e Entry) int64 {
+	size := int64(len(key)) + int64(len(e.Body)) + int64(len(e.ETag))
+	for k, vs := range e.Header {
+		size += int64(len(k))
+		for _, v := range vs {
+			size += int64(len(v))
+		}
+	}
+	return size
+}
+
+type lruNode struct {
+	key   string
+	entry Entry
+	size  int64
+}
+
+// LRU is an in-memory Cache backed by a doubly linked list and map,
+// evicting the least recently used entries once maxBytes is exceeded.
+type LRU struct {
+	mu       sync.Mutex
+	maxBytes int64
+	curBytes int64
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+// NewLRU creates an in-memory cache capped at maxBytes of estimated
+// entry size.
+func NewLRU(maxBytes int64) *LRU {
+	return &LRU{
+		maxBytes: maxBytes,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *LRU) Get(key string) (Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return Entry{}, false
+	}
+	c.ll.MoveToFront(el)
+	return el.Value.(*lruNode).entry, true
+}
+
+func (c *LRU) Set(key string, entry Entry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	size := entrySize(key, entry)
+
+	if el, ok := c.items[key]; ok {
+		node := el.Value.(*lruNode)
+		c.curBytes += size - node.size
+		node.entry = entry
+		node.size = size
+		c.ll.MoveToFront(el)
+	} else {
+		node := &lruNode{key: key, entry: entry, size: size}
+		el := c.ll.PushFront(node)
+		c.items[key] = el
+		c.curBytes += size
+	}
+
+	for c.curBytes > c.maxBytes && c.ll.Len() > 0 {
+		c.evictOldest()
+	}
+	return nil
+}
+
+// evictOldest removes the least recently used entry. Callers must hold mu.
+func (c *LRU) evictOldest() {
+	el := c.ll.Back()
+	if el == nil {
+		return
+	}
+	node := el.Value.(*lruNode)
+	c.ll.Remove(el)
+	delete(c.items, node.key)
+	c.curBytes -= node.size
+}
+
+func (c *LRU) Delete(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil
+	}
+	node := el.Value.(*lruNode)
+	c.ll.Remove(el)
+	delete(c.items, key)
+	c.curBytes -= node.size
+	return nil
+}
+
+func (c *LRU) Purge(prefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for key, el := range c.items {
+		if prefix == "" || hasPrefix(key, prefix) {
+			node := el.Value.(*lruNode)
+			c.ll.Remove(el)
+			delete(c.items, key)
+			c.curBytes -= node.size
+			removed++
+		}
+	}
+	return removed
+}
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func entryOfSize(n int) Entry {
+	return NewEntry(make([]byte, n), nil, 200, "", time.Minute, time.Now())
+}
+
+func TestLRUGetSet(t *testing.T) {
+	c := NewLRU(1024)
+
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("Get on empty cache returned ok=true")
+	}
+
+	c.Set("a", entryOfSize(10))
+	got, ok := c.Get("a")
+	if !ok {
+		t.Fatal("Get(a) returned ok=false after Set")
+	}
+	if len(got.Body) != 10 {
+		t.Fatalf("Get(a) body length = %d, want 10", len(got.Body))
+	}
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	// entrySize counts the key's bytes too, so each of these single-byte
+	// keyed, 10-byte-body entries actually costs 11 bytes. Cap at 30: two
+	// coexist (22), but a third (33) forces an eviction.
+	c := NewLRU(30)
+
+	c.Set("a", entryOfSize(10))
+	c.Set("b", entryOfSize(10))
+	// Touch "a" so "b" becomes the least recently used entry.
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("Get(a) = false, want true")
+	}
+
+	c.Set("c", entryOfSize(10))
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("Get(b) = true after eviction, want false (b was least recently used)")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("Get(a) = false, want true (a was touched before b and should survive)")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatal("Get(c) = false, want true (c was just inserted)")
+	}
+}
+
+func TestLRURespectsMaxBytes(t *testing.T) {
+	c := NewLRU(25)
+
+	for i, key := range []string{"a", "b", "c", "d"} {
+		c.Set(key, entryOfSize(10))
+		if c.curBytes > c.maxBytes {
+			t.Fatalf("after Set #%d (%q): curBytes = %d, exceeds maxBytes = %d", i, key, c.curBytes, c.maxBytes)
+		}
+	}
+}
+
+func TestLRUDeleteAndPurge(t *testing.T) {
+	c := NewLRU(1024)
+	c.Set("player/1", entryOfSize(5))
+	c.Set("player/2", entryOfSize(5))
+	c.Set("vtc/1", entryOfSize(5))
+
+	if err := c.Delete("player/1"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if _, ok := c.Get("player/1"); ok {
+		t.Fatal("Get(player/1) = true after Delete, want false")
+	}
+
+	removed := c.Purge("player/")
+	if removed != 1 {
+		t.Fatalf("Purge(player/) removed %d entries, want 1", removed)
+	}
+	if _, ok := c.Get("player/2"); ok {
+		t.Fatal("Get(player/2) = true after Purge, want false")
+	}
+	if _, ok := c.Get("vtc/1"); !ok {
+		t.Fatal("Get(vtc/1) = false after Purge(player/), want true (unrelated prefix)")
+	}
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis is a Cache backed by a Redis instance, used when the proxy is
+// deployed across multiple replicas that need to share cached
+// responses.
+type Redis struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedis creates a Redis-backed Cache. addr is host:port, e.g.
+// "localhost:6379". Keys are namespaced under prefix to allow the same
+// Redis instance to be shared with other services.
+func NewRedis(addr, password string, db int, prefix string) *Redis {
+	return &Redis{
+		client: redis.NewClient(&redis.Options{
+			Addr:     addr,
+			Password: password,
+			DB:       db,
+		}),
+		prefix: prefix,
+	}
+}
+
+func (c *Redis) namespaced(key string) string {
+	return c.prefix + key
+}
+
+func (c *Redis) Get(key string) (Entry, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	raw, err := c.client.Get(ctx, c.namespaced(key)).Bytes()
+	if err != nil {
+		return Entry{}, false
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return Entry{}, false
+	}
+	return entry, true
+}
+
+func (c *Redis) Set(key string, entry Entry) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	ttl := time.Until(entry.StaleUntil)
+	if ttl <= 0 {
+		ttl = time.Second
+	}
+	return c.client.Set(ctx, c.namespaced(key), raw, ttl).Err()
+}
+
+func (c *Redis) Delete(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return c.client.Del(ctx, c.namespaced(key)).Err()
+}
+
+// Purge scans for keys under prefix and removes them. It is O(n) in the
+// number of matching keys and intended for operator-triggered use
+// (e.g. the /admin/cache/purge endpoint), not hot-path invalidation.
+func (c *Redis) Purge(prefix string) int {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pattern := c.namespaced(prefix) + "*"
+	removed := 0
+	iter := c.client.Scan(ctx, 0, pattern, 100).Iterator()
+	for iter.Next(ctx) {
+		if err := c.client.Del(ctx, iter.Val()).Err(); err == nil {
+			removed++
+		}
+	}
+	return removed
+}
@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"os"
+	"strconv"
+)
+
+// defaultMaxBytes is the default size cap for the in-memory LRU when
+// CACHE_MAX_BYTES is not set.
+const defaultMaxBytes = 64 * 1024 * 1024 // 64MB
+
+// NewFromEnv builds a Cache backend based on environment variables,
+// defaulting to an in-memory LRU. Setting REDIS_ADDR switches to the
+// Redis-backed store so multiple proxy replicas can share a cache.
+func NewFromEnv() Cache {
+	if addr := os.Getenv("REDIS_ADDR"); addr != "" {
+		db, _ := strconv.Atoi(os.Getenv("REDIS_DB"))
+		prefix := os.Getenv("REDIS_PREFIX")
+		if prefix == "" {
+			prefix = "tmp-proxy:"
+		}
+		return NewRedis(addr, os.Getenv("REDIS_PASSWORD"), db, prefix)
+	}
+
+	maxBytes := int64(defaultMaxBytes)
+	if v := os.Getenv("CACHE_MAX_BYTES"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
+			maxBytes = n
+		}
+	}
+	return NewLRU(maxBytes)
+}
@@ -0,0 +1,62 @@
+// Package client is a thin, typed wrapper over the generated
+// TruckersMPProxy gRPC client, for bots and services that want typed
+// access instead of parsing raw JSON from the REST routes.
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	truckersmpv1 "github.com/HarmanPreet-Singh-XYT/truckersmp-api-proxy/proto"
+)
+
+// Client is a connected TruckersMPProxy client.
+type Client struct {
+	conn *grpc.ClientConn
+	rpc  truckersmpv1.TruckersMPProxyClient
+}
+
+// Dial connects to a TruckersMPProxy gRPC server at addr (e.g.
+// "localhost:4005"). The connection is insecure (no TLS); wrap addr
+// behind a TLS-terminating proxy for untrusted networks.
+func Dial(addr string) (*Client, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, rpc: truckersmpv1.NewTruckersMPProxyClient(conn)}, nil
+}
+
+// Close tears down the underlying connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) GetPlayer(ctx context.Context, id int64) (*truckersmpv1.Player, error) {
+	return c.rpc.GetPlayer(ctx, &truckersmpv1.GetPlayerRequest{Id: id})
+}
+
+func (c *Client) GetBan(ctx context.Context, id int64) (*truckersmpv1.Ban, error) {
+	return c.rpc.GetBan(ctx, &truckersmpv1.GetBanRequest{Id: id})
+}
+
+func (c *Client) ListServers(ctx context.Context) (*truckersmpv1.ListServersResponse, error) {
+	return c.rpc.ListServers(ctx, &truckersmpv1.ListServersRequest{})
+}
+
+func (c *Client) GetVTC(ctx context.Context, id int64) (*truckersmpv1.VTC, error) {
+	return c.rpc.GetVTC(ctx, &truckersmpv1.GetVTCRequest{Id: id})
+}
+
+func (c *Client) GetEvent(ctx context.Context, id int64) (*truckersmpv1.Event, error) {
+	return c.rpc.GetEvent(ctx, &truckersmpv1.GetEventRequest{Id: id})
+}
+
+// StreamServers opens a server-streaming RPC yielding one Server message
+// per server each time the proxy's realtime hub reports that the
+// upstream server list changed.
+func (c *Client) StreamServers(ctx context.Context) (truckersmpv1.TruckersMPProxy_StreamServersClient, error) {
+	return c.rpc.StreamServers(ctx, &truckersmpv1.ListServersRequest{})
+}
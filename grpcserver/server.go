@@ -0,0 +1,189 @@
+// Package grpcserver implements the TruckersMPProxy gRPC service
+// defined in proto/truckersmp.proto. It reuses the same upstream.Client
+// (and therefore the same cache) as the REST handlers in main.go, so
+// REST and gRPC consumers see identical upstream behavior.
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	truckersmpv1 "github.com/HarmanPreet-Singh-XYT/truckersmp-api-proxy/proto"
+	"github.com/HarmanPreet-Singh-XYT/truckersmp-api-proxy/realtime"
+	"github.com/HarmanPreet-Singh-XYT/truckersmp-api-proxy/upstream"
+)
+
+// Server implements truckersmpv1.TruckersMPProxyServer.
+type Server struct {
+	truckersmpv1.UnimplementedTruckersMPProxyServer
+
+	upstream *upstream.Client
+	hub      *realtime.Hub
+}
+
+// New builds a Server backed by up. hub is the same realtime.Hub the
+// REST SSE/WebSocket routes use, so StreamServers rides the hub's
+// "servers" poller instead of running its own.
+func New(up *upstream.Client, hub *realtime.Hub) *Server {
+	return &Server{upstream: up, hub: hub}
+}
+
+func (s *Server) get(ctx context.Context, endpoint string, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, upstream.DeadlineFor(endpoint))
+	defer cancel()
+
+	key := upstream.Key(http.MethodGet, endpoint, nil)
+	result, err := s.upstream.Get(ctx, endpoint, key, http.Header{"Accept": []string{"application/json"}})
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "fetching %s: %v", endpoint, err)
+	}
+	if result.Status >= 400 {
+		return status.Errorf(codes.NotFound, "upstream returned %d for %s", result.Status, endpoint)
+	}
+	if err := json.Unmarshal(result.Body, out); err != nil {
+		return status.Errorf(codes.Internal, "decoding %s response: %v", endpoint, err)
+	}
+	return nil
+}
+
+func (s *Server) GetPlayer(ctx context.Context, req *truckersmpv1.GetPlayerRequest) (*truckersmpv1.Player, error) {
+	var raw struct {
+		ID     int64  `json:"id"`
+		Name   string `json:"name"`
+		Banned bool   `json:"banned"`
+		VTC    struct {
+			ID int64 `json:"id"`
+		} `json:"vtc"`
+	}
+	if err := s.get(ctx, fmt.Sprintf("/player/%d", req.Id), &raw); err != nil {
+		return nil, err
+	}
+	return &truckersmpv1.Player{
+		Id:     raw.ID,
+		Name:   raw.Name,
+		Banned: raw.Banned,
+		VtcId:  raw.VTC.ID,
+	}, nil
+}
+
+func (s *Server) GetBan(ctx context.Context, req *truckersmpv1.GetBanRequest) (*truckersmpv1.Ban, error) {
+	var raw struct {
+		ID         int64  `json:"id"`
+		Reason     string `json:"reason"`
+		Active     bool   `json:"active"`
+		Expiration string `json:"expiration"`
+	}
+	if err := s.get(ctx, fmt.Sprintf("/bans/%d", req.Id), &raw); err != nil {
+		return nil, err
+	}
+	return &truckersmpv1.Ban{
+		Id:         raw.ID,
+		Reason:     raw.Reason,
+		Active:     raw.Active,
+		Expiration: raw.Expiration,
+	}, nil
+}
+
+func (s *Server) ListServers(ctx context.Context, _ *truckersmpv1.ListServersRequest) (*truckersmpv1.ListServersResponse, error) {
+	servers, err := s.fetchServers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &truckersmpv1.ListServersResponse{Servers: servers}, nil
+}
+
+// StreamServers subscribes to the hub's "servers" topic - the same one
+// GET /stream/servers serves off of - and pushes a full snapshot, read
+// through the same response cache as ListServers, each time the hub
+// reports the upstream value changed, rather than polling /servers on
+// its own schedule.
+func (s *Server) StreamServers(_ *truckersmpv1.ListServersRequest, stream truckersmpv1.TruckersMPProxy_StreamServersServer) error {
+	events, unsubscribe := s.hub.Subscribe("servers", 0)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case _, ok := <-events:
+			if !ok {
+				return nil
+			}
+			servers, err := s.fetchServers(stream.Context())
+			if err != nil {
+				return err
+			}
+			for _, srv := range servers {
+				if err := stream.Send(srv); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func (s *Server) fetchServers(ctx context.Context) ([]*truckersmpv1.Server, error) {
+	var raw struct {
+		Response []struct {
+			ID      int64  `json:"id"`
+			Name    string `json:"name"`
+			Players int32  `json:"players"`
+			MaxSlot int32  `json:"maxplayers"`
+			Online  bool   `json:"online"`
+		} `json:"response"`
+	}
+	if err := s.get(ctx, "/servers", &raw); err != nil {
+		return nil, err
+	}
+
+	servers := make([]*truckersmpv1.Server, 0, len(raw.Response))
+	for _, srv := range raw.Response {
+		servers = append(servers, &truckersmpv1.Server{
+			Id:         srv.ID,
+			Name:       srv.Name,
+			Players:    srv.Players,
+			MaxPlayers: srv.MaxSlot,
+			Online:     srv.Online,
+		})
+	}
+	return servers, nil
+}
+
+func (s *Server) GetVTC(ctx context.Context, req *truckersmpv1.GetVTCRequest) (*truckersmpv1.VTC, error) {
+	var raw struct {
+		ID           int64  `json:"id"`
+		Name         string `json:"name"`
+		Tag          string `json:"tag"`
+		MembersCount int32  `json:"members_count"`
+	}
+	if err := s.get(ctx, fmt.Sprintf("/vtc/%d", req.Id), &raw); err != nil {
+		return nil, err
+	}
+	return &truckersmpv1.VTC{
+		Id:           raw.ID,
+		Name:         raw.Name,
+		Tag:          raw.Tag,
+		MembersCount: raw.MembersCount,
+	}, nil
+}
+
+func (s *Server) GetEvent(ctx context.Context, req *truckersmpv1.GetEventRequest) (*truckersmpv1.Event, error) {
+	var raw struct {
+		ID      int64  `json:"id"`
+		Name    string `json:"name"`
+		StartAt string `json:"start_at"`
+	}
+	if err := s.get(ctx, fmt.Sprintf("/events/%d", req.Id), &raw); err != nil {
+		return nil, err
+	}
+	return &truckersmpv1.Event{
+		Id:      raw.ID,
+		Name:    raw.Name,
+		StartAt: raw.StartAt,
+	}, nil
+}
@@ -1,96 +1,172 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"io"
 	"log"
+	"net"
 	"net/http"
+	_ "net/http/pprof"
 	"os"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"google.golang.org/grpc"
+
+	"github.com/HarmanPreet-Singh-XYT/truckersmp-api-proxy/cache"
+	"github.com/HarmanPreet-Singh-XYT/truckersmp-api-proxy/grpcserver"
+	"github.com/HarmanPreet-Singh-XYT/truckersmp-api-proxy/plugin"
+	truckersmpv1 "github.com/HarmanPreet-Singh-XYT/truckersmp-api-proxy/proto"
+	"github.com/HarmanPreet-Singh-XYT/truckersmp-api-proxy/realtime"
+	"github.com/HarmanPreet-Singh-XYT/truckersmp-api-proxy/telemetry"
+	"github.com/HarmanPreet-Singh-XYT/truckersmp-api-proxy/upstream"
 )
 
 const (
-	TruckersMPAPIBase = "https://api.truckersmp.com/v2"
-	ServerPort        = ":4004"
+	ServerPort     = ":4004"
+	GRPCServerPort = ":4005"
 )
 
 type ProxyServer struct {
-	client *http.Client
+	upstream *upstream.Client
+	hub      *realtime.Hub
 }
 
 func NewProxyServer() *ProxyServer {
-	return &ProxyServer{
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+	p := &ProxyServer{
+		upstream: upstream.New(cache.NewFromEnv()),
 	}
+	p.hub = realtime.NewHub(p.startTopicPoller)
+	return p
 }
 
-// Generic proxy handler that forwards requests to TruckersMP API
-func (p *ProxyServer) proxyRequest(c *gin.Context, endpoint string) {
-	// Build the full URL
-	url := TruckersMPAPIBase + endpoint
-
-	// Create the request
-	req, err := http.NewRequest(c.Request.Method, url, nil)
-	req.Header.Set("User-Agent", "PostmanRuntime/7.36.1")
-	req.Header.Set("Accept", "application/json")
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   true,
-			"message": "Failed to create request",
+// startTopicPoller adapts a realtime topic name (e.g. "servers" or
+// "player:1234") to an upstream endpoint and begins polling it,
+// publishing every fetched value to the hub via publish.
+func (p *ProxyServer) startTopicPoller(topic string, publish func(interface{})) func() {
+	endpoint := topicEndpoint(topic)
+	fetch := func(string) (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), upstream.DeadlineFor(endpoint))
+		defer cancel()
+
+		result, err := p.upstream.Do(ctx, http.MethodGet, endpoint, http.Header{
+			"Accept": []string{"application/json"},
 		})
-		return
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if err := json.Unmarshal(result.Body, &value); err != nil {
+			return nil, err
+		}
+		return value, nil
 	}
+	return realtime.StartPoller(topic, fetch, realtime.DefaultPollerConfig, publish)
+}
 
-	// Copy headers from original request
-	for key, values := range c.Request.Header {
-		for _, value := range values {
-			req.Header.Add(key, value)
-		}
+// topicEndpoint maps a stream/websocket topic to its upstream REST
+// endpoint. "player:1234" polls /player/1234; everything else maps
+// directly, e.g. "servers" -> /servers.
+func topicEndpoint(topic string) string {
+	const playerPrefix = "player:"
+	if len(topic) > len(playerPrefix) && topic[:len(playerPrefix)] == playerPrefix {
+		return "/player/" + topic[len(playerPrefix):]
 	}
+	return "/" + topic
+}
 
-	// Make the request
-	resp, err := p.client.Do(req)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+// clientClosedRequest is the nginx-popularized (if non-standard) status
+// code for "the client disconnected before we could respond".
+const clientClosedRequest = 499
+
+// writeUpstreamError renders err as a structured JSON body, mapping
+// client cancellation to 499 and upstream timeouts to 504 so callers
+// can tell "you gave up" apart from "TruckersMP is slow" apart from a
+// genuine proxy failure.
+func writeUpstreamError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		c.JSON(clientClosedRequest, gin.H{
 			"error":   true,
-			"message": "Failed to fetch data from TruckersMP API",
+			"message": "Client closed request",
 		})
-		return
-	}
-	defer resp.Body.Close()
-
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
+	case errors.Is(err, context.DeadlineExceeded):
+		c.JSON(http.StatusGatewayTimeout, gin.H{
+			"error":   true,
+			"message": "Timed out waiting for TruckersMP API",
+		})
+	default:
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   true,
-			"message": "Failed to read response",
+			"message": "Failed to fetch data from TruckersMP API",
 		})
-		return
 	}
+}
 
-	// Copy response headers
-	for key, values := range resp.Header {
+func writeResult(c *gin.Context, result upstream.Result) {
+	for key, values := range result.Header {
 		for _, value := range values {
 			c.Header(key, value)
 		}
 	}
+	c.Status(result.Status)
 
-	// Set the status code and return the response
-	c.Status(resp.StatusCode)
-
-	// Try to parse as JSON and return formatted, otherwise return raw
 	var jsonResponse interface{}
-	if json.Unmarshal(body, &jsonResponse) == nil {
-		c.JSON(resp.StatusCode, jsonResponse)
+	if json.Unmarshal(result.Body, &jsonResponse) == nil {
+		c.JSON(result.Status, jsonResponse)
 	} else {
-		c.Data(resp.StatusCode, resp.Header.Get("Content-Type"), body)
+		c.Data(result.Status, result.Header.Get("Content-Type"), result.Body)
+	}
+}
+
+// Generic proxy handler that forwards requests to TruckersMP API
+func (p *ProxyServer) proxyRequest(c *gin.Context, endpoint string) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), upstream.DeadlineFor(endpoint))
+	defer cancel()
+
+	start := time.Now()
+	var (
+		result upstream.Result
+		err    error
+	)
+	if c.Request.Method == http.MethodGet {
+		key := upstream.Key(c.Request.Method, endpoint, c.Request.URL.Query())
+		result, err = p.upstream.Get(ctx, endpoint, key, c.Request.Header)
+	} else {
+		result, err = p.upstream.Do(ctx, c.Request.Method, endpoint, c.Request.Header)
+	}
+	c.Set("upstream_duration", time.Since(start))
+
+	if err != nil {
+		writeUpstreamError(c, err)
+		return
+	}
+	c.Set("cache_hit", result.Cached)
+	c.Set("upstream_status", result.Status)
+	writeResult(c, result)
+}
+
+// requirePprofToken gates /debug/pprof behind a shared secret read
+// from PPROF_TOKEN, since pprof output can expose memory contents and
+// is meant for operators, not public consumption. If PPROF_TOKEN isn't
+// set, pprof is disabled entirely rather than left open.
+func requirePprofToken() gin.HandlerFunc {
+	token := os.Getenv("PPROF_TOKEN")
+	return func(c *gin.Context) {
+		if token == "" || c.GetHeader("X-Pprof-Token") != token {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+				"error":   true,
+				"message": "Endpoint not found",
+			})
+			return
+		}
+		c.Next()
 	}
 }
 
@@ -109,7 +185,8 @@ func validateID(c *gin.Context, paramName string) (int, bool) {
 }
 
 func (p *ProxyServer) setupRoutes() *gin.Engine {
-	r := gin.Default()
+	r := gin.New()
+	r.Use(gin.Recovery(), telemetry.GinLogger(), otelgin.Middleware("truckersmp-api-proxy"))
 
 	// Get allowed origin from environment variable
 	allowedOrigin := os.Getenv("ORIGIN")
@@ -131,6 +208,30 @@ func (p *ProxyServer) setupRoutes() *gin.Engine {
 		c.Next()
 	})
 
+	// Plugin pipeline, configured from PLUGIN_CONFIG (YAML or JSON). The
+	// pipeline runs ahead of every route below, including health and
+	// admin endpoints. With PLUGIN_CONFIG unset, or a config listing no
+	// enabled plugins, no plugins run and behavior is unchanged; a
+	// config file that cannot be read or built stops the process.
+	if path := os.Getenv("PLUGIN_CONFIG"); path != "" {
+		cfg, err := plugin.LoadPipelineConfig(path)
+		if err != nil {
+			log.Fatalf("Failed to load plugin pipeline config: %v", err)
+		}
+		chain, err := plugin.Build(cfg)
+		if err != nil {
+			log.Fatalf("Failed to build plugin pipeline: %v", err)
+		}
+		r.Use(chain.Middleware())
+	}
+
+	// Prometheus metrics
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// pprof, gated on a shared token since it can leak memory contents
+	// and is meant for operators, not the public internet.
+	r.GET("/debug/pprof/*action", requirePprofToken(), gin.WrapH(http.DefaultServeMux))
+
 	// Health check endpoint
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -140,6 +241,41 @@ func (p *ProxyServer) setupRoutes() *gin.Engine {
 		})
 	})
 
+	// Admin endpoints
+	r.POST("/admin/cache/purge", func(c *gin.Context) {
+		removed := p.upstream.Purge(c.Query("prefix"))
+		c.JSON(http.StatusOK, gin.H{
+			"purged": removed,
+		})
+	})
+
+	r.GET("/admin/cache/stats", func(c *gin.Context) {
+		hits, misses := p.upstream.Stats()
+		c.JSON(http.StatusOK, gin.H{
+			"hits":   hits,
+			"misses": misses,
+		})
+	})
+
+	// Real-time streaming endpoints
+	r.GET("/stream/servers", func(c *gin.Context) {
+		realtime.ServeSSE(c.Writer, c.Request, p.hub, "servers")
+	})
+
+	r.GET("/stream/player/:id", func(c *gin.Context) {
+		if id, valid := validateID(c, "id"); valid {
+			realtime.ServeSSE(c.Writer, c.Request, p.hub, fmt.Sprintf("player:%d", id))
+		}
+	})
+
+	r.GET("/stream/events", func(c *gin.Context) {
+		realtime.ServeSSE(c.Writer, c.Request, p.hub, "events")
+	})
+
+	r.GET("/ws", func(c *gin.Context) {
+		realtime.ServeWS(c.Writer, c.Request, p.hub)
+	})
+
 	// Player endpoints
 	r.GET("/player/:id", func(c *gin.Context) {
 		if id, valid := validateID(c, "id"); valid {
@@ -281,21 +417,45 @@ func (p *ProxyServer) setupRoutes() *gin.Engine {
 	return r
 }
 
+// runGRPCServer starts the gRPC listener on addr, serving the same
+// upstream client (and therefore the same cache) and realtime hub as
+// the REST routes.
+func runGRPCServer(addr string, up *upstream.Client, hub *realtime.Hub) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("Failed to listen on %s for gRPC: %v", addr, err)
+	}
+
+	grpcSrv := grpc.NewServer()
+	truckersmpv1.RegisterTruckersMPProxyServer(grpcSrv, grpcserver.New(up, hub))
+
+	log.Printf("Starting TruckersMP gRPC server on %s", addr)
+	if err := grpcSrv.Serve(lis); err != nil {
+		log.Fatalf("gRPC server stopped: %v", err)
+	}
+}
+
 func main() {
+	restAddr := flag.String("rest-addr", ServerPort, "address for the REST (Gin) server")
+	grpcAddr := flag.String("grpc-addr", GRPCServerPort, "address for the gRPC server")
+	flag.Parse()
+
 	// Set Gin to release mode in production
 	gin.SetMode(gin.ReleaseMode)
 
 	// Create proxy server
 	proxy := NewProxyServer()
 
+	go runGRPCServer(*grpcAddr, proxy.upstream, proxy.hub)
+
 	// Setup routes
 	router := proxy.setupRoutes()
 
 	// Start server
-	log.Printf("Starting TruckersMP API Proxy Server on port %s", ServerPort)
-	log.Printf("Proxying requests to: %s", TruckersMPAPIBase)
+	log.Printf("Starting TruckersMP API Proxy Server on port %s", *restAddr)
+	log.Printf("Proxying requests to: %s", upstream.APIBase)
 
-	if err := router.Run(ServerPort); err != nil {
+	if err := router.Run(*restAddr); err != nil {
 		log.Fatal("Failed to start server:", err)
 	}
 }
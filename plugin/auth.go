@@ -0,0 +1,89 @@
+package plugin
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	Register("auth", func() Plugin { return &AuthPlugin{} })
+}
+
+// keyConfig is the per-API-key settings: which route prefixes it may
+// reach. An empty Scopes list means the key may reach every route.
+type keyConfig struct {
+	Scopes []string `json:"scopes"`
+}
+
+// AuthPlugin checks for an API key (via "X-API-Key" or an
+// "Authorization: Bearer <key>" header) and rejects requests whose key
+// is unknown or whose scopes don't cover the requested route.
+type AuthPlugin struct {
+	keys map[string]keyConfig
+}
+
+func (p *AuthPlugin) Name() string { return "auth" }
+
+func (p *AuthPlugin) Configure(raw json.RawMessage) error {
+	var cfg struct {
+		Keys map[string]keyConfig `json:"keys"`
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return err
+	}
+	p.keys = cfg.Keys
+	return nil
+}
+
+func (p *AuthPlugin) Handle(c *gin.Context, next func()) {
+	key := extractKey(c.Request)
+	if key == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error":   true,
+			"message": "Missing API key",
+		})
+		return
+	}
+
+	cfg, ok := p.keys[key]
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error":   true,
+			"message": "Invalid API key",
+		})
+		return
+	}
+
+	if len(cfg.Scopes) > 0 && !scopesAllow(cfg.Scopes, c.Request.URL.Path) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error":   true,
+			"message": "API key does not permit this route",
+		})
+		return
+	}
+
+	c.Set("api_key", key)
+	next()
+}
+
+func extractKey(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	return ""
+}
+
+func scopesAllow(scopes []string, path string) bool {
+	for _, scope := range scopes {
+		if strings.HasPrefix(path, scope) {
+			return true
+		}
+	}
+	return false
+}
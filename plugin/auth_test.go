@@ -0,0 +1,96 @@
+package plugin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func testContext(method, target string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, target, nil)
+	return c, w
+}
+
+func newAuthPlugin(t *testing.T, cfg string) *AuthPlugin {
+	t.Helper()
+	p := &AuthPlugin{}
+	if err := p.Configure(json.RawMessage(cfg)); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+	return p
+}
+
+func TestAuthPluginRejectsMissingKey(t *testing.T) {
+	p := newAuthPlugin(t, `{"keys":{}}`)
+	c, w := testContext(http.MethodGet, "/servers")
+
+	called := false
+	p.Handle(c, func() { called = true })
+
+	if called {
+		t.Fatal("next() was called with no API key present")
+	}
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestAuthPluginRejectsUnknownKey(t *testing.T) {
+	p := newAuthPlugin(t, `{"keys":{"good-key":{}}}`)
+	c, w := testContext(http.MethodGet, "/servers")
+	c.Request.Header.Set("X-API-Key", "bad-key")
+
+	called := false
+	p.Handle(c, func() { called = true })
+
+	if called {
+		t.Fatal("next() was called with an unknown API key")
+	}
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestAuthPluginRejectsOutOfScopeRoute(t *testing.T) {
+	p := newAuthPlugin(t, `{"keys":{"good-key":{"scopes":["/player"]}}}`)
+	c, w := testContext(http.MethodGet, "/vtc/1")
+	c.Request.Header.Set("X-API-Key", "good-key")
+
+	called := false
+	p.Handle(c, func() { called = true })
+
+	if called {
+		t.Fatal("next() was called for a route outside the key's scopes")
+	}
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestAuthPluginAllowsScopedRequestViaBearerToken(t *testing.T) {
+	p := newAuthPlugin(t, `{"keys":{"good-key":{"scopes":["/player"]}}}`)
+	c, w := testContext(http.MethodGet, "/player/123")
+	c.Request.Header.Set("Authorization", "Bearer good-key")
+
+	called := false
+	p.Handle(c, func() { called = true })
+
+	if !called {
+		t.Fatal("next() was not called for an allowed key+route")
+	}
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (unchanged by Handle)", w.Code, http.StatusOK)
+	}
+	if key, ok := c.Get("api_key"); !ok || key != "good-key" {
+		t.Fatalf("api_key context value = %v, %v, want %q, true", key, ok, "good-key")
+	}
+}
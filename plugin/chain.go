@@ -0,0 +1,90 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// StageConfig is one entry in the pipeline config: which plugin to
+// run and its raw (plugin-specific) settings.
+type StageConfig struct {
+	Name    string                 `mapstructure:"name"`
+	Enabled bool                   `mapstructure:"enabled"`
+	Config  map[string]interface{} `mapstructure:"config"`
+}
+
+// PipelineConfig is the full pipeline.yaml/.json shape: an ordered
+// list of stages applied to every route, front to back.
+type PipelineConfig struct {
+	Plugins []StageConfig `mapstructure:"plugins"`
+}
+
+// LoadPipelineConfig reads a YAML or JSON pipeline config from path
+// using viper, so either format works based on the file extension.
+func LoadPipelineConfig(path string) (PipelineConfig, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+
+	var cfg PipelineConfig
+	if err := v.ReadInConfig(); err != nil {
+		return cfg, fmt.Errorf("plugin: reading pipeline config: %w", err)
+	}
+	if err := v.Unmarshal(&cfg); err != nil {
+		return cfg, fmt.Errorf("plugin: parsing pipeline config: %w", err)
+	}
+	return cfg, nil
+}
+
+// Chain is the ordered list of configured, enabled plugins that make
+// up a pipeline.
+type Chain struct {
+	stages []Plugin
+}
+
+// Build constructs a Chain from cfg, instantiating and configuring
+// every enabled plugin via the global registry.
+func Build(cfg PipelineConfig) (*Chain, error) {
+	chain := &Chain{}
+	for _, stage := range cfg.Plugins {
+		if !stage.Enabled {
+			continue
+		}
+
+		p, err := New(stage.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		raw, err := json.Marshal(stage.Config)
+		if err != nil {
+			return nil, fmt.Errorf("plugin: marshaling config for %q: %w", stage.Name, err)
+		}
+		if err := p.Configure(raw); err != nil {
+			return nil, fmt.Errorf("plugin: configuring %q: %w", stage.Name, err)
+		}
+
+		chain.stages = append(chain.stages, p)
+	}
+	return chain, nil
+}
+
+// Middleware returns a single gin.HandlerFunc that runs every stage in
+// order, each wrapping the next via Handle's next() callback.
+func (chain *Chain) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		chain.run(c, 0)
+	}
+}
+
+func (chain *Chain) run(c *gin.Context, index int) {
+	if index >= len(chain.stages) {
+		c.Next()
+		return
+	}
+	chain.stages[index].Handle(c, func() {
+		chain.run(c, index+1)
+	})
+}
@@ -0,0 +1,140 @@
+package plugin
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	Register("circuit_breaker", func() Plugin { return &CircuitBreakerPlugin{} })
+}
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+// CircuitBreakerPlugin tracks a rolling window of upstream outcomes
+// (recorded by Handle after the route handler runs; a 5xx response
+// counts as a failure) and short-circuits
+// requests to a 503 once the error rate crosses Threshold, giving the
+// upstream OpenDuration to recover before probing again.
+type CircuitBreakerPlugin struct {
+	mu sync.Mutex
+
+	window       []bool // true = success, in arrival order
+	windowSize   int
+	threshold    float64
+	openDuration time.Duration
+	state        breakerState
+	openedAt     time.Time
+}
+
+func (p *CircuitBreakerPlugin) Name() string { return "circuit_breaker" }
+
+func (p *CircuitBreakerPlugin) Configure(raw json.RawMessage) error {
+	var cfg struct {
+		WindowSize     int     `json:"window_size"`
+		ErrorThreshold float64 `json:"error_threshold"`
+		OpenDurationMs int     `json:"open_duration_ms"`
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return err
+	}
+	if cfg.WindowSize <= 0 {
+		cfg.WindowSize = 20
+	}
+	if cfg.ErrorThreshold <= 0 {
+		cfg.ErrorThreshold = 0.5
+	}
+	if cfg.OpenDurationMs <= 0 {
+		cfg.OpenDurationMs = 5000
+	}
+
+	p.windowSize = cfg.WindowSize
+	p.threshold = cfg.ErrorThreshold
+	p.openDuration = time.Duration(cfg.OpenDurationMs) * time.Millisecond
+	return nil
+}
+
+func (p *CircuitBreakerPlugin) Handle(c *gin.Context, next func()) {
+	if !p.allowRequest() {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+			"error":   true,
+			"message": "Upstream is degraded; circuit breaker open",
+		})
+		return
+	}
+
+	next()
+
+	p.RecordOutcome(c.Writer.Status() < 500)
+}
+
+// allowRequest decides, under the current breaker state, whether a
+// request may proceed to the upstream.
+func (p *CircuitBreakerPlugin) allowRequest() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch p.state {
+	case stateOpen:
+		if time.Since(p.openedAt) >= p.openDuration {
+			p.state = stateHalfOpen
+			return true
+		}
+		return false
+	case stateHalfOpen:
+		// A single probe is already in flight (the request that made
+		// the stateOpen->stateHalfOpen transition above); every other
+		// concurrent request is held back until RecordOutcome resolves
+		// it to stateClosed or back to stateOpen.
+		return false
+	default:
+		return true
+	}
+}
+
+// RecordOutcome feeds a single request's success/failure into the
+// rolling window and re-evaluates whether the breaker should trip.
+func (p *CircuitBreakerPlugin) RecordOutcome(success bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.state == stateHalfOpen {
+		if success {
+			p.state = stateClosed
+			p.window = nil
+		} else {
+			p.state = stateOpen
+			p.openedAt = time.Now()
+		}
+		return
+	}
+
+	p.window = append(p.window, success)
+	if len(p.window) > p.windowSize {
+		p.window = p.window[len(p.window)-p.windowSize:]
+	}
+	if len(p.window) < p.windowSize {
+		return
+	}
+
+	failures := 0
+	for _, ok := range p.window {
+		if !ok {
+			failures++
+		}
+	}
+	if float64(failures)/float64(len(p.window)) >= p.threshold {
+		p.state = stateOpen
+		p.openedAt = time.Now()
+	}
+}
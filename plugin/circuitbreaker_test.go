@@ -0,0 +1,100 @@
+package plugin
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func newCircuitBreakerPlugin(t *testing.T, cfg string) *CircuitBreakerPlugin {
+	t.Helper()
+	p := &CircuitBreakerPlugin{}
+	if err := p.Configure([]byte(cfg)); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+	return p
+}
+
+func TestCircuitBreakerTripsOnceThresholdExceeded(t *testing.T) {
+	p := newCircuitBreakerPlugin(t, `{"window_size":4,"error_threshold":0.5,"open_duration_ms":50}`)
+
+	p.RecordOutcome(true)
+	p.RecordOutcome(true)
+	if !p.allowRequest() {
+		t.Fatal("breaker tripped before the window filled")
+	}
+
+	p.RecordOutcome(false)
+	p.RecordOutcome(false)
+
+	if p.allowRequest() {
+		t.Fatal("allowRequest = true once the failure rate crossed the threshold, want false")
+	}
+}
+
+func TestCircuitBreakerHalfOpenAllowsExactlyOneProbe(t *testing.T) {
+	p := newCircuitBreakerPlugin(t, `{"window_size":2,"error_threshold":0.5,"open_duration_ms":1}`)
+
+	p.RecordOutcome(false)
+	p.RecordOutcome(false)
+	if p.allowRequest() {
+		t.Fatal("breaker should be open immediately after tripping")
+	}
+
+	time.Sleep(5 * time.Millisecond) // let openDuration elapse so the next call sees stateHalfOpen
+
+	const callers = 20
+	var wg sync.WaitGroup
+	var allowed int32
+	var mu sync.Mutex
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			if p.allowRequest() {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 1 {
+		t.Fatalf("allowRequest returned true for %d concurrent callers during half-open, want exactly 1", allowed)
+	}
+}
+
+func TestCircuitBreakerProbeSuccessCloses(t *testing.T) {
+	p := newCircuitBreakerPlugin(t, `{"window_size":2,"error_threshold":0.5,"open_duration_ms":1}`)
+
+	p.RecordOutcome(false)
+	p.RecordOutcome(false)
+	time.Sleep(5 * time.Millisecond)
+
+	if !p.allowRequest() {
+		t.Fatal("probe was not allowed through after openDuration elapsed")
+	}
+	p.RecordOutcome(true)
+
+	if !p.allowRequest() {
+		t.Fatal("breaker did not close after a successful probe")
+	}
+}
+
+func TestCircuitBreakerProbeFailureReopens(t *testing.T) {
+	p := newCircuitBreakerPlugin(t, `{"window_size":2,"error_threshold":0.5,"open_duration_ms":1}`)
+
+	p.RecordOutcome(false)
+	p.RecordOutcome(false)
+	time.Sleep(5 * time.Millisecond)
+
+	if !p.allowRequest() {
+		t.Fatal("probe was not allowed through after openDuration elapsed")
+	}
+	p.RecordOutcome(false)
+
+	if p.allowRequest() {
+		t.Fatal("allowRequest = true immediately after a failed probe, want the breaker to stay open")
+	}
+}
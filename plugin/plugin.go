@@ -0,0 +1,51 @@
+// Package plugin implements an ordered middleware pipeline for the
+// proxy, in the spirit of APISIX's plugin model: each plugin is a
+// small, independently configurable unit (auth, rate limiting, circuit
+// breaking, ...) chained in front of the route handlers.
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Plugin is implemented by every pipeline stage. Configure is called
+// once at startup with that plugin's raw config block; Handle runs on
+// every request and must call next() to continue the chain, or return
+// without calling it to short-circuit (after writing its own
+// response).
+type Plugin interface {
+	Name() string
+	Configure(raw json.RawMessage) error
+	Handle(c *gin.Context, next func())
+}
+
+// Factory constructs a fresh, unconfigured Plugin instance. Plugins
+// register a Factory under their name so the pipeline can be built
+// purely from config without the router importing every plugin
+// package directly.
+type Factory func() Plugin
+
+var registry = map[string]Factory{}
+
+// Register adds a plugin factory under name. It panics on duplicate
+// registration, which only happens from a programming error (two
+// plugins claiming the same name) at init time.
+func Register(name string, factory Factory) {
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("plugin: %q already registered", name))
+	}
+	registry[name] = factory
+}
+
+// New looks up the factory registered under name and returns a fresh
+// Plugin instance.
+func New(name string) (Plugin, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("plugin: no plugin registered with name %q", name)
+	}
+	return factory(), nil
+}
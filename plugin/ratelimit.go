@@ -0,0 +1,196 @@
+package plugin
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	Register("rate_limit", func() Plugin { return &RateLimitPlugin{} })
+}
+
+// bucketIdleTTL is how long a bucket may go unused before the reaper
+// reclaims it. It must comfortably exceed the reaper's own sweep
+// interval (bucketReapInterval) so a bucket isn't reaped mid-burst.
+const bucketIdleTTL = 10 * time.Minute
+
+// bucketReapInterval is how often idle buckets are swept out of the
+// map.
+const bucketReapInterval = 2 * time.Minute
+
+// bucket is a single token bucket, refilled continuously at
+// ratePerSec and capped at burst tokens.
+type bucket struct {
+	mu         sync.Mutex
+	tokens     float64
+	updatedAt  time.Time
+	ratePerSec float64
+	burst      float64
+}
+
+func (b *bucket) allow() (bool, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(b.updatedAt).Seconds()
+	b.updatedAt = now
+	b.tokens += elapsed * b.ratePerSec
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+
+	if b.tokens < 1 {
+		return false, 0
+	}
+	b.tokens--
+	return true, int(b.tokens)
+}
+
+func (b *bucket) idleSince(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return now.Sub(b.updatedAt)
+}
+
+// routeOverride lets a specific route prefix use a different rate
+// than the plugin-wide default.
+type routeOverride struct {
+	Prefix         string `json:"prefix"`
+	RequestsPerMin int    `json:"requests_per_minute"`
+	Burst          int    `json:"burst"`
+}
+
+// RateLimitPlugin is a per-key (falling back to per-IP) token-bucket
+// rate limiter, with optional per-route overrides of the default
+// limit.
+type RateLimitPlugin struct {
+	defaultRatePerSec float64
+	defaultBurst      float64
+	overrides         []routeOverride
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+func (p *RateLimitPlugin) Name() string { return "rate_limit" }
+
+func (p *RateLimitPlugin) Configure(raw json.RawMessage) error {
+	var cfg struct {
+		RequestsPerMinute int             `json:"requests_per_minute"`
+		Burst             int             `json:"burst"`
+		Overrides         []routeOverride `json:"overrides"`
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return err
+	}
+	if cfg.RequestsPerMinute <= 0 {
+		cfg.RequestsPerMinute = 60
+	}
+	if cfg.Burst <= 0 {
+		cfg.Burst = cfg.RequestsPerMinute
+	}
+
+	p.defaultRatePerSec = float64(cfg.RequestsPerMinute) / 60
+	p.defaultBurst = float64(cfg.Burst)
+	p.overrides = cfg.Overrides
+	p.buckets = make(map[string]*bucket)
+
+	go p.reapIdleBuckets()
+	return nil
+}
+
+func (p *RateLimitPlugin) Handle(c *gin.Context, next func()) {
+	identity := rateLimitIdentity(c)
+	route := routeTemplate(c)
+	ratePerSec, burst := p.limitFor(route)
+
+	b := p.bucketFor(identity+"|"+route, ratePerSec, burst)
+	allowed, remaining := b.allow()
+
+	c.Header("X-RateLimit-Limit", strconv.Itoa(int(burst)))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+
+	if !allowed {
+		c.Header("Retry-After", "1")
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+			"error":   true,
+			"message": "Rate limit exceeded",
+		})
+		return
+	}
+
+	next()
+}
+
+func (p *RateLimitPlugin) limitFor(path string) (ratePerSec, burst float64) {
+	for _, o := range p.overrides {
+		if len(path) >= len(o.Prefix) && path[:len(o.Prefix)] == o.Prefix {
+			return float64(o.RequestsPerMin) / 60, float64(o.Burst)
+		}
+	}
+	return p.defaultRatePerSec, p.defaultBurst
+}
+
+func (p *RateLimitPlugin) bucketFor(key string, ratePerSec, burst float64) *bucket {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	b, ok := p.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, updatedAt: time.Now(), ratePerSec: ratePerSec, burst: burst}
+		p.buckets[key] = b
+	}
+	return b
+}
+
+// reapIdleBuckets periodically drops buckets nobody has touched in
+// bucketIdleTTL, so distinct (client, route) pairs don't accumulate
+// forever on a proxy whose routes carry dynamic resource IDs
+// (/player/:id, /vtc/:id, ...). It runs for the lifetime of the
+// plugin, which is itself process-lifetime.
+func (p *RateLimitPlugin) reapIdleBuckets() {
+	ticker := time.NewTicker(bucketReapInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		p.reapOnce(time.Now())
+	}
+}
+
+// reapOnce removes every bucket idle for more than bucketIdleTTL as of
+// now. Split out from reapIdleBuckets so the sweep itself can be
+// exercised without waiting on a real ticker.
+func (p *RateLimitPlugin) reapOnce(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, b := range p.buckets {
+		if b.idleSince(now) > bucketIdleTTL {
+			delete(p.buckets, key)
+		}
+	}
+}
+
+// routeTemplate returns the matched route pattern (e.g. "/player/:id")
+// rather than the raw request path, so rate-limit buckets are keyed
+// per route rather than per distinct resource ID. It falls back to the
+// raw path for unmatched requests (e.g. the catch-all 404 handler).
+func routeTemplate(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return c.Request.URL.Path
+}
+
+func rateLimitIdentity(c *gin.Context) string {
+	if key, ok := c.Get("api_key"); ok {
+		return key.(string)
+	}
+	return c.ClientIP()
+}
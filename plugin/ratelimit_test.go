@@ -0,0 +1,75 @@
+package plugin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newRateLimitPlugin(t *testing.T, cfg string) *RateLimitPlugin {
+	t.Helper()
+	p := &RateLimitPlugin{}
+	if err := p.Configure([]byte(cfg)); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+	return p
+}
+
+func TestRateLimitPluginKeysBucketsByRouteTemplateNotPath(t *testing.T) {
+	p := newRateLimitPlugin(t, `{"requests_per_minute":60,"burst":1}`)
+
+	engine := gin.New()
+	engine.GET("/player/:id", func(c *gin.Context) {
+		p.Handle(c, func() {})
+	})
+
+	for _, id := range []string{"1", "2", "3"} {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/player/"+id, nil))
+	}
+
+	if len(p.buckets) != 1 {
+		t.Fatalf("buckets = %d, want 1 (all /player/:id requests should share one bucket)", len(p.buckets))
+	}
+}
+
+func TestRateLimitPluginBlocksOnceBurstExhausted(t *testing.T) {
+	p := newRateLimitPlugin(t, `{"requests_per_minute":60,"burst":1}`)
+
+	c, _ := testContext(http.MethodGet, "/servers")
+	called := false
+	p.Handle(c, func() { called = true })
+	if !called {
+		t.Fatal("first request within burst was rate-limited")
+	}
+
+	c2, w2 := testContext(http.MethodGet, "/servers")
+	called2 := false
+	p.Handle(c2, func() { called2 = true })
+	if called2 {
+		t.Fatal("second request beyond burst was not rate-limited")
+	}
+	if w2.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", w2.Code, http.StatusTooManyRequests)
+	}
+}
+
+func TestRateLimitPluginReapOnceRemovesOnlyIdleBuckets(t *testing.T) {
+	p := newRateLimitPlugin(t, `{"requests_per_minute":60,"burst":1}`)
+
+	now := time.Now()
+	p.buckets["idle"] = &bucket{updatedAt: now.Add(-bucketIdleTTL - time.Minute)}
+	p.buckets["active"] = &bucket{updatedAt: now}
+
+	p.reapOnce(now)
+
+	if _, ok := p.buckets["idle"]; ok {
+		t.Fatal("reapOnce left an idle-past-TTL bucket in place")
+	}
+	if _, ok := p.buckets["active"]; !ok {
+		t.Fatal("reapOnce removed a recently-used bucket")
+	}
+}
@@ -0,0 +1,47 @@
+package plugin
+
+import (
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	Register("transform", func() Plugin { return &TransformPlugin{} })
+}
+
+// TransformPlugin adds or overrides request/response headers. It's a
+// deliberately small building block for operators who need to inject
+// an upstream header (e.g. a shared API key) without writing a Go
+// plugin. Response headers are set before the route handler runs, so a
+// header the handler sets afterwards (such as one copied from the
+// upstream response) replaces them; such headers cannot be stripped.
+type TransformPlugin struct {
+	addRequestHeaders  map[string]string
+	addResponseHeaders map[string]string
+}
+
+func (p *TransformPlugin) Name() string { return "transform" }
+
+func (p *TransformPlugin) Configure(raw json.RawMessage) error {
+	var cfg struct {
+		AddRequestHeaders  map[string]string `json:"add_request_headers"`
+		AddResponseHeaders map[string]string `json:"add_response_headers"`
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return err
+	}
+	p.addRequestHeaders = cfg.AddRequestHeaders
+	p.addResponseHeaders = cfg.AddResponseHeaders
+	return nil
+}
+
+func (p *TransformPlugin) Handle(c *gin.Context, next func()) {
+	for k, v := range p.addRequestHeaders {
+		c.Request.Header.Set(k, v)
+	}
+	for k, v := range p.addResponseHeaders {
+		c.Header(k, v)
+	}
+	next()
+}
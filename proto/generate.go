@@ -0,0 +1,8 @@
+// Package truckersmpv1 holds the generated protobuf/gRPC types for
+// the TruckersMPProxy service. Run `go generate ./...` (protoc and the
+// protoc-gen-go/protoc-gen-go-grpc plugins must be on PATH) to
+// regenerate truckersmp.pb.go and truckersmp_grpc.pb.go from
+// truckersmp.proto.
+package truckersmpv1
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative truckersmp.proto
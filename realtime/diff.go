@@ -0,0 +1,63 @@
+package realtime
+
+import "encoding/json"
+
+// computeDiff returns a JSON-encoded compact diff between old and
+// next, or nil if they're equal. On the first call for a topic (old is
+// nil) the full value is returned so the first subscriber gets a
+// complete snapshot. The diff is a flat map of changed top-level keys;
+// this is intentionally simpler than full JSON Patch since most
+// TruckersMP payloads are shallow objects or arrays.
+func computeDiff(old, next interface{}) []byte {
+	if old == nil {
+		raw, err := json.Marshal(next)
+		if err != nil {
+			return nil
+		}
+		return raw
+	}
+
+	oldMap, oldIsMap := old.(map[string]interface{})
+	nextMap, nextIsMap := next.(map[string]interface{})
+	if !oldIsMap || !nextIsMap {
+		// Not shallow objects (e.g. arrays) - fall back to whole-value
+		// diffs since there's no stable key to diff by.
+		oldRaw, _ := json.Marshal(old)
+		nextRaw, _ := json.Marshal(next)
+		if string(oldRaw) == string(nextRaw) {
+			return nil
+		}
+		return nextRaw
+	}
+
+	changed := map[string]interface{}{}
+	for k, v := range nextMap {
+		ov, existed := oldMap[k]
+		if !existed || !jsonEqual(ov, v) {
+			changed[k] = v
+		}
+	}
+	for k := range oldMap {
+		if _, stillPresent := nextMap[k]; !stillPresent {
+			changed[k] = nil // tombstone: key removed
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+	raw, err := json.Marshal(changed)
+	if err != nil {
+		return nil
+	}
+	return raw
+}
+
+func jsonEqual(a, b interface{}) bool {
+	ra, errA := json.Marshal(a)
+	rb, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return string(ra) == string(rb)
+}
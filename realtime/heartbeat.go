@@ -0,0 +1,9 @@
+package realtime
+
+import "time"
+
+// newHeartbeatTicker is a thin wrapper so SSE and WebSocket handlers
+// share the same keepalive cadence.
+func newHeartbeatTicker() *time.Ticker {
+	return time.NewTicker(HeartbeatInterval)
+}
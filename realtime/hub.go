@@ -0,0 +1,208 @@
+// Package realtime fans out upstream changes to subscribers over
+// Server-Sent Events and WebSocket, polling each resource at most once
+// per interval no matter how many clients are watching it.
+package realtime
+
+import (
+	"sync"
+	"time"
+)
+
+// Event is a single change notification delivered to subscribers of a
+// topic. ID is monotonically increasing per topic and is used for SSE
+// Last-Event-ID resume.
+type Event struct {
+	ID    uint64
+	Topic string
+	// Data is the compact diff (or full payload on the first event for
+	// the topic) to send to subscribers.
+	Data []byte
+}
+
+// clientBuffer is how many pending events a slow client may accumulate
+// before the hub starts dropping the oldest ones.
+const clientBuffer = 32
+
+// ringSize is how many recent events per topic are retained for
+// Last-Event-ID resume.
+const ringSize = 64
+
+// client is a single subscriber's bounded, drop-oldest mailbox.
+type client struct {
+	mu     sync.Mutex
+	ch     chan Event
+	closed bool
+}
+
+func newClient() *client {
+	return &client{ch: make(chan Event, clientBuffer)}
+}
+
+// send delivers ev to the client, dropping the oldest queued event
+// first if the mailbox is full rather than blocking the publisher. It
+// is a no-op once the client has been closed, so a publish racing
+// with unsubscribe never writes to (and panics on) a closed channel.
+func (c *client) send(ev Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+
+	select {
+	case c.ch <- ev:
+		return
+	default:
+	}
+	select {
+	case <-c.ch:
+	default:
+	}
+	select {
+	case c.ch <- ev:
+	default:
+	}
+}
+
+// close marks the client closed and closes its channel, unblocking
+// any reader (e.g. forwardWS in realtime/ws.go) waiting on it. Safe to
+// call concurrently with send.
+func (c *client) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+	close(c.ch)
+}
+
+// topicState holds the subscribers and recent-event ring buffer for a
+// single topic, plus the refcounted poller control needed to stop
+// polling once nobody is listening.
+type topicState struct {
+	mu        sync.Mutex
+	clients   map[*client]struct{}
+	ring      []Event
+	nextID    uint64
+	stopPoll  func()
+	lastValue interface{}
+}
+
+// Hub is a topic-keyed pub/sub. Subscribing to a topic with no running
+// poller starts that topic's poller; unsubscribing the last client stops
+// it. A topic's ring buffer and event IDs are kept after its poller
+// stops.
+type Hub struct {
+	mu     sync.Mutex
+	topics map[string]*topicState
+	start  func(topic string, publish func(interface{})) (stop func())
+}
+
+// NewHub creates a Hub. start is called whenever a topic gains a
+// subscriber while it has no running poller; it must begin polling the resource and call publish
+// whenever a new value is fetched, and return a func to stop polling.
+func NewHub(start func(topic string, publish func(interface{})) (stop func())) *Hub {
+	return &Hub{
+		topics: make(map[string]*topicState),
+		start:  start,
+	}
+}
+
+// Subscribe registers a new client for topic, starting the topic's
+// poller if none is running, and returns the client's event channel
+// plus an unsubscribe func. Buffered events with an ID after
+// lastEventID are replayed immediately; a lastEventID of zero replays
+// every buffered event.
+func (h *Hub) Subscribe(topic string, lastEventID uint64) (<-chan Event, func()) {
+	h.mu.Lock()
+	ts, ok := h.topics[topic]
+	if !ok {
+		ts = &topicState{clients: make(map[*client]struct{})}
+		h.topics[topic] = ts
+	}
+	if ts.stopPoll == nil {
+		ts.stopPoll = h.start(topic, func(value interface{}) {
+			h.publish(topic, value)
+		})
+	}
+	h.mu.Unlock()
+
+	cl := newClient()
+
+	ts.mu.Lock()
+	ts.clients[cl] = struct{}{}
+	for _, ev := range ts.ring {
+		if ev.ID > lastEventID {
+			cl.send(ev)
+		}
+	}
+	ts.mu.Unlock()
+
+	unsubscribe := func() {
+		ts.mu.Lock()
+		delete(ts.clients, cl)
+		empty := len(ts.clients) == 0
+		ts.mu.Unlock()
+		cl.close()
+
+		if empty {
+			h.mu.Lock()
+			// Re-check under the hub lock in case a new subscriber
+			// raced in between the unlock above and here. The topic
+			// state (and its ring buffer) is kept so a reconnecting
+			// client can still resume from Last-Event-ID.
+			ts.mu.Lock()
+			stillEmpty := len(ts.clients) == 0
+			ts.mu.Unlock()
+			if stillEmpty && ts.stopPoll != nil {
+				ts.stopPoll()
+				ts.stopPoll = nil
+			}
+			h.mu.Unlock()
+		}
+	}
+
+	return cl.ch, unsubscribe
+}
+
+// publish computes a diff against the topic's last known value, and if
+// anything changed, appends it to the ring buffer and fans it out to
+// every subscriber.
+func (h *Hub) publish(topic string, value interface{}) {
+	h.mu.Lock()
+	ts, ok := h.topics[topic]
+	h.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	ts.mu.Lock()
+	diff := computeDiff(ts.lastValue, value)
+	ts.lastValue = value
+	if diff == nil {
+		ts.mu.Unlock()
+		return
+	}
+
+	ts.nextID++
+	ev := Event{ID: ts.nextID, Topic: topic, Data: diff}
+	ts.ring = append(ts.ring, ev)
+	if len(ts.ring) > ringSize {
+		ts.ring = ts.ring[len(ts.ring)-ringSize:]
+	}
+
+	clients := make([]*client, 0, len(ts.clients))
+	for cl := range ts.clients {
+		clients = append(clients, cl)
+	}
+	ts.mu.Unlock()
+
+	for _, cl := range clients {
+		cl.send(ev)
+	}
+}
+
+// HeartbeatInterval is how often the SSE and WebSocket handlers send a
+// keepalive ping to idle subscribers.
+const HeartbeatInterval = 15 * time.Second
@@ -0,0 +1,124 @@
+package realtime
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestHub builds a Hub whose poller is controlled entirely by the
+// test: calling the returned publish func pushes a value for topic,
+// and stopped reports whether stop has been called for that topic.
+func newTestHub() (hub *Hub, publish func(topic string, value interface{}), stopped func(topic string) bool) {
+	publishers := map[string]func(interface{}){}
+	stops := map[string]bool{}
+
+	hub = NewHub(func(topic string, pub func(interface{})) func() {
+		publishers[topic] = pub
+		return func() { stops[topic] = true }
+	})
+
+	publish = func(topic string, value interface{}) {
+		publishers[topic](value)
+	}
+	stopped = func(topic string) bool {
+		return stops[topic]
+	}
+	return hub, publish, stopped
+}
+
+func recvWithTimeout(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+	select {
+	case ev, ok := <-events:
+		if !ok {
+			t.Fatal("event channel closed while waiting for an event")
+		}
+		return ev
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return Event{}
+}
+
+func TestHubDeliversPublishedEvents(t *testing.T) {
+	hub, publish, _ := newTestHub()
+
+	events, unsubscribe := hub.Subscribe("servers", 0)
+	defer unsubscribe()
+
+	publish("servers", map[string]interface{}{"count": 1})
+
+	ev := recvWithTimeout(t, events)
+	if ev.Topic != "servers" {
+		t.Fatalf("Event.Topic = %q, want %q", ev.Topic, "servers")
+	}
+	if ev.ID != 1 {
+		t.Fatalf("Event.ID = %d, want 1 (first event for the topic)", ev.ID)
+	}
+}
+
+func TestHubUnsubscribeClosesChannel(t *testing.T) {
+	hub, _, _ := newTestHub()
+
+	events, unsubscribe := hub.Subscribe("servers", 0)
+	unsubscribe()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("read off the event channel after unsubscribe succeeded with ok=true, want the channel closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("reading from the event channel after unsubscribe blocked, want it closed")
+	}
+}
+
+func TestHubStopsPollerOnceLastSubscriberLeaves(t *testing.T) {
+	hub, _, stopped := newTestHub()
+
+	events1, unsubscribe1 := hub.Subscribe("servers", 0)
+	_, unsubscribe2 := hub.Subscribe("servers", 0)
+
+	unsubscribe1()
+	if stopped("servers") {
+		t.Fatal("poller was stopped while a subscriber remained")
+	}
+	drainClosed(events1)
+
+	unsubscribe2()
+	if !stopped("servers") {
+		t.Fatal("poller was not stopped once the last subscriber left")
+	}
+}
+
+func drainClosed(events <-chan Event) {
+	for range events {
+	}
+}
+
+func TestHubReplaysOnlyEventsAfterLastEventID(t *testing.T) {
+	hub, publish, _ := newTestHub()
+
+	events, unsubscribe := hub.Subscribe("servers", 0)
+	publish("servers", map[string]interface{}{"count": 1}) // id 1
+	publish("servers", map[string]interface{}{"count": 2}) // id 2
+	publish("servers", map[string]interface{}{"count": 3}) // id 3
+	recvWithTimeout(t, events)
+	recvWithTimeout(t, events)
+	recvWithTimeout(t, events)
+	unsubscribe()
+
+	late, unsubscribeLate := hub.Subscribe("servers", 2)
+	defer unsubscribeLate()
+
+	ev := recvWithTimeout(t, late)
+	if ev.ID != 3 {
+		t.Fatalf("replayed Event.ID = %d, want 3 (only events after lastEventID=2)", ev.ID)
+	}
+
+	select {
+	case extra := <-late:
+		t.Fatalf("received an unexpected extra replayed event: %+v", extra)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
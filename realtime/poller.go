@@ -0,0 +1,69 @@
+package realtime
+
+import "time"
+
+// FetchFunc retrieves the current value for a topic, e.g. by calling
+// the upstream TruckersMP API.
+type FetchFunc func(topic string) (interface{}, error)
+
+// PollerConfig controls the adaptive polling interval: it starts at
+// Min, and backs off towards Max each time a poll comes back
+// unchanged, resetting to Min as soon as something changes.
+type PollerConfig struct {
+	Min    time.Duration
+	Max    time.Duration
+	Factor float64
+}
+
+// DefaultPollerConfig is the adaptive polling schedule main.go's
+// startTopicPoller uses for every topic.
+var DefaultPollerConfig = PollerConfig{
+	Min:    2 * time.Second,
+	Max:    30 * time.Second,
+	Factor: 1.5,
+}
+
+// StartPoller begins polling fetch(topic) on an adaptive interval and
+// calls publish with every value it retrieves (publish itself is
+// responsible for diffing and no-op suppression). It returns a stop
+// func that ends the polling goroutine.
+func StartPoller(topic string, fetch FetchFunc, cfg PollerConfig, publish func(interface{})) func() {
+	stop := make(chan struct{})
+
+	go func() {
+		interval := cfg.Min
+		var last interface{}
+
+		timer := time.NewTimer(interval)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-timer.C:
+				value, err := fetch(topic)
+				if err == nil {
+					publish(value)
+					if !jsonEqual(last, value) {
+						interval = cfg.Min
+					} else {
+						interval = nextInterval(interval, cfg)
+					}
+					last = value
+				}
+				timer.Reset(interval)
+			}
+		}
+	}()
+
+	return func() { close(stop) }
+}
+
+func nextInterval(current time.Duration, cfg PollerConfig) time.Duration {
+	next := time.Duration(float64(current) * cfg.Factor)
+	if next > cfg.Max {
+		return cfg.Max
+	}
+	return next
+}
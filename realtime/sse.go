@@ -0,0 +1,51 @@
+package realtime
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// ServeSSE subscribes w/r to topic and streams events as
+// Server-Sent-Events until the client disconnects. It honors
+// Last-Event-ID for resume and sends a heartbeat comment every
+// HeartbeatInterval to keep idle connections (and proxies) alive.
+func ServeSSE(w http.ResponseWriter, r *http.Request, hub *Hub, topic string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	var lastEventID uint64
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		if parsed, err := strconv.ParseUint(id, 10, 64); err == nil {
+			lastEventID = parsed
+		}
+	}
+
+	events, unsubscribe := hub.Subscribe(topic, lastEventID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := newHeartbeatTicker()
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev := <-events:
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", ev.ID, ev.Topic, ev.Data)
+			flusher.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		}
+	}
+}
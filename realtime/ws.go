@@ -0,0 +1,123 @@
+package realtime
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+var upgrader = websocket.Upgrader{
+	// The upgrader accepts any origin. The surrounding Gin CORS
+	// middleware only sets response headers and does not reject
+	// cross-origin WebSocket upgrades.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// wsFrame is the shape of every client->server message over /ws.
+type wsFrame struct {
+	Action string `json:"action"`
+	Topic  string `json:"topic"`
+}
+
+// wsEvent is the shape of every server->client message over /ws.
+type wsEvent struct {
+	Topic string          `json:"topic"`
+	ID    uint64          `json:"id"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// ServeWS upgrades the connection and multiplexes any number of topic
+// subscriptions requested by the client via
+// {"action":"subscribe","topic":"..."} frames, until the connection
+// closes.
+func ServeWS(w http.ResponseWriter, r *http.Request, hub *Hub) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var writeMu sync.Mutex
+	unsubscribers := map[string]func(){}
+	var subsMu sync.Mutex
+
+	defer func() {
+		subsMu.Lock()
+		for _, unsub := range unsubscribers {
+			unsub()
+		}
+		subsMu.Unlock()
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			var frame wsFrame
+			if err := conn.ReadJSON(&frame); err != nil {
+				return
+			}
+
+			switch frame.Action {
+			case "subscribe":
+				subsMu.Lock()
+				if _, already := unsubscribers[frame.Topic]; already {
+					subsMu.Unlock()
+					continue
+				}
+				events, unsubscribe := hub.Subscribe(frame.Topic, 0)
+				unsubscribers[frame.Topic] = unsubscribe
+				subsMu.Unlock()
+
+				go forwardWS(conn, &writeMu, events, done)
+			case "unsubscribe":
+				subsMu.Lock()
+				if unsubscribe, ok := unsubscribers[frame.Topic]; ok {
+					unsubscribe()
+					delete(unsubscribers, frame.Topic)
+				}
+				subsMu.Unlock()
+			}
+		}
+	}()
+
+	heartbeat := newHeartbeatTicker()
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-heartbeat.C:
+			writeMu.Lock()
+			err := conn.WriteMessage(websocket.PingMessage, nil)
+			writeMu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// forwardWS relays a single topic's events to conn until the
+// subscription is cancelled or the connection is torn down.
+func forwardWS(conn *websocket.Conn, writeMu *sync.Mutex, events <-chan Event, done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			writeMu.Lock()
+			err := conn.WriteJSON(wsEvent{Topic: ev.Topic, ID: ev.ID, Data: ev.Data})
+			writeMu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
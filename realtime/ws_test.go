@@ -0,0 +1,86 @@
+package realtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func newWSTestServer(hub *Hub) (*httptest.Server, string) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeWS(w, r, hub)
+	}))
+	return srv, "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func TestServeWSForwardsSubscribedTopicEvents(t *testing.T) {
+	hub, publish, _ := newTestHub()
+	srv, wsURL := newWSTestServer(hub)
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Dial returned error: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(wsFrame{Action: "subscribe", Topic: "servers"}); err != nil {
+		t.Fatalf("WriteJSON(subscribe) returned error: %v", err)
+	}
+
+	// Give ServeWS's reader goroutine time to register the subscription
+	// before we publish, since Subscribe runs asynchronously relative to
+	// this test.
+	time.Sleep(50 * time.Millisecond)
+	publish("servers", map[string]interface{}{"count": 1})
+
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	var got wsEvent
+	if err := conn.ReadJSON(&got); err != nil {
+		t.Fatalf("ReadJSON returned error: %v", err)
+	}
+	if got.Topic != "servers" {
+		t.Fatalf("wsEvent.Topic = %q, want %q", got.Topic, "servers")
+	}
+	if got.ID != 1 {
+		t.Fatalf("wsEvent.ID = %d, want 1", got.ID)
+	}
+}
+
+func TestServeWSUnsubscribeStopsForwarding(t *testing.T) {
+	hub, publish, stopped := newTestHub()
+	srv, wsURL := newWSTestServer(hub)
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Dial returned error: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(wsFrame{Action: "subscribe", Topic: "servers"}); err != nil {
+		t.Fatalf("WriteJSON(subscribe) returned error: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if err := conn.WriteJSON(wsFrame{Action: "unsubscribe", Topic: "servers"}); err != nil {
+		t.Fatalf("WriteJSON(unsubscribe) returned error: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if !stopped("servers") {
+		t.Fatal("poller was not stopped after the only subscriber unsubscribed")
+	}
+
+	publish("servers", map[string]interface{}{"count": 1})
+
+	conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	var got wsEvent
+	if err := conn.ReadJSON(&got); err == nil {
+		t.Fatalf("ReadJSON succeeded after unsubscribe, want no further events; got %+v", got)
+	}
+}
@@ -0,0 +1,68 @@
+package telemetry
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// Log is the process-wide structured logger, writing JSON to stdout.
+var Log = zerolog.New(os.Stdout).With().Timestamp().Logger()
+
+// requestIDKey is the gin.Context key the request ID is stashed under
+// so handlers can correlate their own logging with the access log line
+// below.
+const requestIDKey = "request_id"
+
+// GinLogger replaces gin.Default()'s console logger with a structured
+// JSON access log: one line per request, including a generated request
+// ID, the matched route template (not the raw path, to keep
+// cardinality bounded), upstream latency, cache outcome and upstream
+// status when the handler stored them in the gin.Context under
+// "upstream_duration", "cache_hit" and "upstream_status".
+func GinLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := uuid.NewString()
+		c.Set(requestIDKey, requestID)
+		c.Header("X-Request-ID", requestID)
+
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := c.Writer.Status()
+
+		RequestsTotal.WithLabelValues(route, strconv.Itoa(status)).Inc()
+
+		event := Log.Info()
+		if len(c.Errors) > 0 {
+			event = Log.Error()
+		}
+		event.
+			Str("request_id", requestID).
+			Str("method", c.Request.Method).
+			Str("route", route).
+			Int("status", status).
+			Dur("duration", time.Since(start)).
+			Str("client_ip", c.ClientIP())
+
+		if v, ok := c.Get("cache_hit"); ok {
+			event.Bool("cache_hit", v.(bool))
+		}
+		if v, ok := c.Get("upstream_status"); ok {
+			event.Int("upstream_status", v.(int))
+		}
+		if v, ok := c.Get("upstream_duration"); ok {
+			event.Dur("upstream_duration", v.(time.Duration))
+		}
+
+		event.Msg("request handled")
+	}
+}
@@ -0,0 +1,43 @@
+// Package telemetry centralizes the proxy's observability surface:
+// Prometheus metrics, structured logging and OpenTelemetry tracing, so
+// operators can diagnose upstream slowdowns and tune the cache/plugin
+// config introduced alongside it.
+package telemetry
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// RequestsTotal counts every REST request by route template and final
+// status code.
+var RequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "proxy_requests_total",
+	Help: "Total REST requests handled, by route and status code.",
+}, []string{"route", "status"})
+
+// UpstreamDuration tracks how long each TruckersMP upstream call took,
+// by route.
+var UpstreamDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "proxy_upstream_duration_seconds",
+	Help:    "Latency of upstream TruckersMP API calls, by route.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"route"})
+
+// CacheHitsTotal and CacheMissesTotal count response cache outcomes.
+var (
+	CacheHitsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "proxy_cache_hits_total",
+		Help: "Total response cache hits (including stale-but-served).",
+	})
+	CacheMissesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "proxy_cache_misses_total",
+		Help: "Total response cache misses.",
+	})
+)
+
+// Inflight tracks how many upstream calls are currently in flight.
+var Inflight = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "proxy_inflight",
+	Help: "Upstream TruckersMP API calls currently in flight.",
+})
@@ -0,0 +1,316 @@
+// Package upstream is the shared TruckersMP API client: it owns the
+// HTTP transport, response cache and request de-duplication, and is
+// used by both the Gin (REST) handlers and the gRPC service so the two
+// protocols see identical caching and upstream behavior.
+package upstream
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/HarmanPreet-Singh-XYT/truckersmp-api-proxy/cache"
+	"github.com/HarmanPreet-Singh-XYT/truckersmp-api-proxy/telemetry"
+)
+
+const (
+	// APIBase is the TruckersMP REST API this proxy fronts.
+	APIBase = "https://api.truckersmp.com/v2"
+	// DefaultCacheTTL is used for routes with no entry in cache.RouteTTL.
+	DefaultCacheTTL = 15 * time.Second
+	// DefaultDeadline bounds how long a single upstream call may take
+	// when the route has no entry in routeDeadlines.
+	DefaultDeadline = 10 * time.Second
+)
+
+// routeDeadlines lets slower upstream routes (e.g. VTC member/news
+// listings) get a longer per-request deadline than the default.
+var routeDeadlines = map[string]time.Duration{
+	"/vtc": 15 * time.Second,
+}
+
+// DeadlineFor returns the configured deadline for endpoint, matching
+// on the longest routeDeadlines prefix, or DefaultDeadline.
+func DeadlineFor(endpoint string) time.Duration {
+	best := DefaultDeadline
+	bestLen := -1
+	for prefix, d := range routeDeadlines {
+		if len(prefix) > bestLen && strings.HasPrefix(endpoint, prefix) {
+			best = d
+			bestLen = len(prefix)
+		}
+	}
+	return best
+}
+
+// Result is a single upstream response, cached or live.
+type Result struct {
+	Status int
+	Header http.Header
+	Body   []byte
+	// Cached reports whether this Result was served from the response
+	// cache (fresh or stale-while-revalidate) rather than fetched live.
+	Cached bool
+}
+
+// Client is the shared upstream HTTP client, response cache and
+// singleflight group. It is safe for concurrent use.
+type Client struct {
+	http    *http.Client
+	baseURL string
+	cache   cache.Cache
+	group   singleflight.Group
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// New builds a Client backed by c. A tuned *http.Transport is used so
+// connection pooling, HTTP/2 and response-header timeouts are
+// consistent regardless of which protocol (REST or gRPC) is driving
+// the client. The transport is wrapped with otelhttp so every upstream
+// call propagates the caller's trace context and produces a span.
+func New(c cache.Cache) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 20
+	transport.IdleConnTimeout = 90 * time.Second
+	transport.ResponseHeaderTimeout = 20 * time.Second
+
+	return &Client{
+		http:    &http.Client{Transport: otelhttp.NewTransport(transport)},
+		baseURL: APIBase,
+		cache:   c,
+	}
+}
+
+// Key builds the cache key for a GET request: method, endpoint and a
+// normalized (sorted) query string, so differently-ordered query
+// params share a cache entry.
+func Key(method, endpoint string, query url.Values) string {
+	// Encode sorts by key, so differently-ordered query strings
+	// produce the same cache key.
+	return method + " " + endpoint + "?" + query.Encode()
+}
+
+// Get performs a cached GET: it honors Cache-Control/ETag from the
+// upstream when present, otherwise the per-route default TTL table,
+// and collapses concurrent misses for the same key into one upstream
+// call via singleflight.
+func (c *Client) Get(ctx context.Context, endpoint, key string, headers http.Header) (Result, error) {
+	now := time.Now()
+
+	if entry, ok := c.cache.Get(key); ok {
+		if entry.Fresh(now) {
+			c.hits.Add(1)
+			telemetry.CacheHitsTotal.Inc()
+			return resultFromEntry(entry), nil
+		}
+		if entry.Stale(now) {
+			c.hits.Add(1)
+			telemetry.CacheHitsTotal.Inc()
+			result := resultFromEntry(entry)
+			go c.group.Do(key, func() (interface{}, error) {
+				c.refresh(endpoint, key, headers)
+				return nil, nil
+			})
+			return result, nil
+		}
+	}
+
+	c.misses.Add(1)
+	telemetry.CacheMissesTotal.Inc()
+
+	v, err, _ := c.group.Do(key, func() (interface{}, error) {
+		return c.fetchAndCache(ctx, endpoint, key, headers)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	return v.(Result), nil
+}
+
+// Do performs an uncached upstream call, used for non-GET methods.
+func (c *Client) Do(ctx context.Context, method, endpoint string, headers http.Header) (Result, error) {
+	status, header, body, err := c.fetch(ctx, method, endpoint, headers)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Status: status, Header: header, Body: body}, nil
+}
+
+// Purge removes every cache entry whose key matches prefix.
+func (c *Client) Purge(prefix string) int {
+	return c.cache.Purge(prefix)
+}
+
+// Stats returns the running hit/miss counters.
+func (c *Client) Stats() (hits, misses int64) {
+	return c.hits.Load(), c.misses.Load()
+}
+
+func (c *Client) fetchAndCache(ctx context.Context, endpoint, key string, headers http.Header) (Result, error) {
+	prior, hasPrior := c.cache.Get(key)
+
+	status, header, body, err := c.fetch(ctx, http.MethodGet, endpoint, conditionalHeaders(headers, prior, hasPrior))
+	if err != nil {
+		return Result{}, err
+	}
+
+	if status == http.StatusNotModified && hasPrior {
+		c.storeRevalidated(endpoint, key, header, prior)
+		return resultFromEntry(prior), nil
+	}
+
+	ttl := ttlForResponse(endpoint, header)
+	c.cache.Set(key, cache.NewEntry(body, header, status, header.Get("ETag"), ttl, time.Now()))
+
+	return Result{Status: status, Header: header, Body: body}, nil
+}
+
+func (c *Client) refresh(endpoint, key string, headers http.Header) {
+	ctx, cancel := context.WithTimeout(context.Background(), DeadlineFor(endpoint))
+	defer cancel()
+
+	prior, hasPrior := c.cache.Get(key)
+
+	status, header, body, err := c.fetch(ctx, http.MethodGet, endpoint, conditionalHeaders(headers, prior, hasPrior))
+	if err != nil {
+		return
+	}
+
+	if status == http.StatusNotModified && hasPrior {
+		c.storeRevalidated(endpoint, key, header, prior)
+		return
+	}
+
+	ttl := ttlForResponse(endpoint, header)
+	c.cache.Set(key, cache.NewEntry(body, header, status, header.Get("ETag"), ttl, time.Now()))
+}
+
+// conditionalHeaders clones incoming and adds If-None-Match when prior
+// carries an ETag worth revalidating against, so a 304 lets us keep
+// serving the cached body instead of re-downloading it.
+func conditionalHeaders(incoming http.Header, prior cache.Entry, hasPrior bool) http.Header {
+	if !hasPrior || prior.ETag == "" {
+		return incoming
+	}
+	headers := incoming.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+	headers.Set("If-None-Match", prior.ETag)
+	return headers
+}
+
+// storeRevalidated re-stores prior's body under a fresh TTL after the
+// upstream confirmed it with a 304, so the grace window starts over
+// without re-downloading anything.
+func (c *Client) storeRevalidated(endpoint, key string, header http.Header, prior cache.Entry) {
+	ttl := ttlForResponse(endpoint, header)
+	c.cache.Set(key, cache.NewEntry(prior.Body, prior.Header, prior.StatusCode, prior.ETag, ttl, time.Now()))
+}
+
+func (c *Client) fetch(ctx context.Context, method, endpoint string, incoming http.Header) (int, http.Header, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+endpoint, nil)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	req.Header.Set("User-Agent", "PostmanRuntime/7.36.1")
+	req.Header.Set("Accept", "application/json")
+	for key, values := range incoming {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	telemetry.Inflight.Inc()
+	defer telemetry.Inflight.Dec()
+	start := time.Now()
+
+	resp, err := c.http.Do(req)
+
+	telemetry.UpstreamDuration.WithLabelValues(routeLabel(endpoint)).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	return resp.StatusCode, resp.Header, body, nil
+}
+
+// routeLabel collapses an endpoint with dynamic segments (e.g.
+// "/player/1234") down to its route template (e.g. "/player/:id") so
+// the proxy_upstream_duration_seconds histogram doesn't grow one label
+// series per distinct ID ever requested.
+func routeLabel(endpoint string) string {
+	segments := strings.Split(strings.Trim(endpoint, "/"), "/")
+	for i, seg := range segments {
+		if _, err := strconv.Atoi(seg); err == nil {
+			segments[i] = ":id"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+func resultFromEntry(entry cache.Entry) Result {
+	return Result{Status: entry.StatusCode, Header: http.Header(entry.Header), Body: entry.Body, Cached: true}
+}
+
+func ttlForResponse(endpoint string, header http.Header) time.Duration {
+	if cc := header.Get("Cache-Control"); cc != "" {
+		if ttl, ok := parseMaxAge(cc); ok {
+			return ttl
+		}
+	}
+	return cache.TTLFor(endpoint, DefaultCacheTTL)
+}
+
+func parseMaxAge(cacheControl string) (time.Duration, bool) {
+	const prefix = "max-age="
+	for _, directive := range splitComma(cacheControl) {
+		if len(directive) > len(prefix) && directive[:len(prefix)] == prefix {
+			if seconds, err := strconv.Atoi(directive[len(prefix):]); err == nil {
+				return time.Duration(seconds) * time.Second, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func splitComma(s string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == ',' {
+			parts = append(parts, trimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	parts = append(parts, trimSpace(s[start:]))
+	return parts
+}
+
+func trimSpace(s string) string {
+	for len(s) > 0 && s[0] == ' ' {
+		s = s[1:]
+	}
+	for len(s) > 0 && s[len(s)-1] == ' ' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,132 @@
+package upstream
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HarmanPreet-Singh-XYT/truckersmp-api-proxy/cache"
+)
+
+func newTestClient(handler http.HandlerFunc) (*Client, *httptest.Server) {
+	srv := httptest.NewServer(handler)
+	c := &Client{
+		http:    srv.Client(),
+		baseURL: srv.URL,
+		cache:   cache.NewLRU(1 << 20),
+	}
+	return c, srv
+}
+
+func TestGetCollapsesConcurrentMisses(t *testing.T) {
+	var upstreamCalls int64
+	c, srv := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&upstreamCalls, 1)
+		time.Sleep(20 * time.Millisecond) // widen the window for concurrent callers to collide
+		w.Write([]byte(`{"ok":true}`))
+	})
+	defer srv.Close()
+
+	const callers = 10
+	var wg sync.WaitGroup
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := c.Get(context.Background(), "/servers", "GET /servers", nil); err != nil {
+				t.Errorf("Get returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt64(&upstreamCalls); got != 1 {
+		t.Fatalf("upstream was called %d times for %d concurrent misses on the same key, want 1", got, callers)
+	}
+}
+
+func TestGetServesStaleWhileRevalidating(t *testing.T) {
+	var upstreamCalls int64
+	refreshed := make(chan struct{})
+	c, srv := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&upstreamCalls, 1)
+		if n == 1 {
+			close(refreshed)
+		}
+		w.Write([]byte(`{"generation":` + time.Now().Format("999999999") + `}`))
+	})
+	defer srv.Close()
+
+	key := "GET /servers"
+	now := time.Now()
+	stale := cache.Entry{
+		Body:       []byte(`{"stale":true}`),
+		StatusCode: 200,
+		StoredAt:   now.Add(-time.Minute),
+		ExpiresAt:  now.Add(-time.Second), // already expired
+		StaleUntil: now.Add(time.Minute),  // still within grace window
+	}
+	c.cache.Set(key, stale)
+
+	result, err := c.Get(context.Background(), "/servers", key, nil)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if !result.Cached {
+		t.Fatal("Result.Cached = false for a stale-but-in-grace entry, want true")
+	}
+	if string(result.Body) != string(stale.Body) {
+		t.Fatalf("Result.Body = %q, want the stale body %q to be served immediately", result.Body, stale.Body)
+	}
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("background refresh never reached the upstream")
+	}
+}
+
+func TestGetRevalidatesExpiredEntryWithETag(t *testing.T) {
+	var gotIfNoneMatch string
+	c, srv := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		gotIfNoneMatch = r.Header.Get("If-None-Match")
+		w.WriteHeader(http.StatusNotModified)
+	})
+	defer srv.Close()
+
+	key := "GET /servers"
+	now := time.Now()
+	expired := cache.Entry{
+		Body:       []byte(`{"cached":true}`),
+		StatusCode: 200,
+		ETag:       `"abc123"`,
+		StoredAt:   now.Add(-time.Hour),
+		ExpiresAt:  now.Add(-time.Hour), // expired and past any stale grace window
+		StaleUntil: now.Add(-time.Hour),
+	}
+	c.cache.Set(key, expired)
+
+	result, err := c.Get(context.Background(), "/servers", key, nil)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+
+	if gotIfNoneMatch != expired.ETag {
+		t.Fatalf("upstream saw If-None-Match %q, want the cached ETag %q", gotIfNoneMatch, expired.ETag)
+	}
+	if string(result.Body) != string(expired.Body) {
+		t.Fatalf("Result.Body = %q, want the revalidated cached body %q to be reused on 304", result.Body, expired.Body)
+	}
+
+	entry, ok := c.cache.Get(key)
+	if !ok {
+		t.Fatal("cache.Get after revalidation = false, want the entry to still be cached")
+	}
+	if !entry.Fresh(now) {
+		t.Fatal("revalidated entry is not Fresh immediately after a 304, want its TTL to have been renewed")
+	}
+}